@@ -2,6 +2,10 @@
 //
 // This package provides a very fast _almost_ lock-free arena allocator for Go. "Almost"
 // lock-free because it locks when expanding the arena after a chunk has been exhausted.
+//
+// By default chunks are allocated on the Go heap, which means the garbage collector
+// still scans them. Use [WithBacking] with [NewMmapBacking] or [NewCBacking] to allocate
+// chunks off-heap instead, which matters for arenas holding many GB of data.
 package arena
 
 import (
@@ -19,11 +23,19 @@ type Arena struct {
 	lock      sync.Mutex
 	chunkSize int64
 	limit     int
+	backing   Backing
 
 	cursor      atomic.Int64
 	chunkCursor int64
 	current     []byte
 	chunks      [][]byte
+	large       [][]byte
+	epoch       atomic.Int64
+
+	sharded bool
+	shards  []*shard
+
+	stats arenaStats
 }
 
 type contextKey struct{}
@@ -43,7 +55,10 @@ func FromContext(ctx context.Context) *Arena {
 // New will typically be inlined.
 func New[T any](arena *Arena) *T {
 	var t T
-	return (*T)(arena.alloc(int(unsafe.Sizeof(t))))
+	n := unsafe.Sizeof(t)
+	p := arena.alignedAlloc(int(n), int(unsafe.Alignof(t)))
+	arena.stats.bytesAllocated.Add(uint64(n))
+	return (*T)(p)
 }
 
 // Value creates space for a new object in the arena and copies "value" into it.
@@ -55,7 +70,9 @@ func New[T any](arena *Arena) *T {
 // Typically value will be inlined and won't escape to the heap.
 func Value[T any](arena *Arena, value T) *T {
 	var t T
-	out := (*T)(arena.alloc(int(unsafe.Sizeof(t))))
+	n := unsafe.Sizeof(t)
+	out := (*T)(arena.alignedAlloc(int(n), int(unsafe.Alignof(t))))
+	arena.stats.bytesAllocated.Add(uint64(n))
 	*out = value
 	return out
 }
@@ -70,7 +87,9 @@ func Value[T any](arena *Arena, value T) *T {
 // Make will typically be inlined.
 func Make[T any](arena *Arena, size, cap int) []T {
 	var t T
-	out := unsafe.Slice((*T)(arena.alloc(int(unsafe.Sizeof(t))*cap)), cap)
+	n := int(unsafe.Sizeof(t)) * cap
+	out := unsafe.Slice((*T)(arena.alignedAlloc(n, int(unsafe.Alignof(t)))), cap)
+	arena.stats.bytesAllocated.Add(uint64(n))
 	return out[:size]
 }
 
@@ -101,7 +120,9 @@ func growSlice[T any](arena *Arena, slice []T, elements []T) []T {
 	for newLen >= capacity {
 		capacity *= 2
 	}
-	out := unsafe.Slice((*T)(arena.alloc(int(unsafe.Sizeof(t))*capacity)), capacity)
+	n := int(unsafe.Sizeof(t)) * capacity
+	out := unsafe.Slice((*T)(arena.alignedAlloc(n, int(unsafe.Alignof(t)))), capacity)
+	arena.stats.bytesAllocated.Add(uint64(n))
 	copy(out, slice)
 	copy(out[len(slice):], elements)
 	return out[:newLen]
@@ -114,6 +135,7 @@ func growSlice[T any](arena *Arena, slice []T, elements []T) []T {
 // String will typically be inlined.
 func String(arena *Arena, value string) string {
 	arenaData := arena.alloc(len(value))
+	arena.stats.bytesAllocated.Add(uint64(len(value)))
 	copy(unsafe.Slice((*byte)(arenaData), len(value)), value)
 	return unsafe.String((*byte)(arenaData), len(value))
 }
@@ -122,35 +144,63 @@ func String(arena *Arena, value string) string {
 type Option func(*Arena)
 
 // WithLimit sets the maximum number of chunks that can be allocated.
+//
+// On a [WithSharded] arena, the limit applies per shard rather than to the
+// arena as a whole, since each shard grows independently: the arena can
+// allocate up to limit*runtime.GOMAXPROCS(0) chunks in total.
 func WithLimit(limit int) Option {
 	return func(a *Arena) {
 		a.limit = limit
 	}
 }
 
+// WithBacking sets the [Backing] used to allocate chunk memory.
+//
+// The default backing allocates chunks on the Go heap. Use [NewMmapBacking]
+// or [NewCBacking] to keep arena memory off-heap and invisible to the
+// garbage collector, which matters for arenas holding many GB of data.
+func WithBacking(backing Backing) Option {
+	return func(a *Arena) {
+		a.backing = backing
+	}
+}
+
 // Create a new Arena with the given chunk size in bytes.
 //
 // The chunk size is the increment by which the arena will allocate new memory.
-// It is also the maximum size for a single object.
+// Objects larger than the chunk size are allocated individually rather than
+// from a chunk.
 //
 // Limit is the maximum number of chunks that can be allocated. A value of 0
 // means there is no limit to the number of chunks that can be allocated.
 func Create(chunkSize int, options ...Option) *Arena {
-	current := make([]byte, chunkSize)
 	a := &Arena{
-		current:   current,
 		chunkSize: int64(chunkSize),
-		chunks:    [][]byte{current},
+		backing:   goBacking{},
 	}
 	for _, option := range options {
 		option(a)
 	}
+	if a.sharded {
+		a.shards = newShards(a, chunkSize)
+		return a
+	}
+	current := a.backing.Alloc(chunkSize)
+	a.current = current
+	a.chunks = [][]byte{current}
+	a.noteChunkAdded()
 	return a
 }
 
 func (a *Arena) alloc(n int) unsafe.Pointer {
+	if int64(n) > a.chunkSize {
+		return a.allocLarge(n)
+	}
+	if a.sharded {
+		return a.allocSharded(n, 1)
+	}
 	next := a.cursor.Add(int64(n))
-	if next < a.chunkSize {
+	if next <= a.chunkSize {
 		return unsafe.Pointer(&a.current[next-int64(n) : next][0])
 	}
 	return a.resize(n, next)
@@ -166,22 +216,119 @@ func (a *Arena) resize(n int, next int64) unsafe.Pointer {
 	if !a.cursor.CompareAndSwap(next, int64(n)) {
 		return a.alloc(n)
 	}
+	a.stats.resizeCount.Add(1)
+	a.stats.wasted.Add(uint64(a.chunkSize - (next - int64(n))))
 	if a.chunkCursor < int64(len(a.chunks)-1) {
 		a.current = a.chunks[a.chunkCursor]
-	} else if len(a.chunks) < a.limit {
-		a.current = make([]byte, a.chunkSize)
+	} else if a.limit == 0 || len(a.chunks) < a.limit {
+		a.current = a.backing.Alloc(int(a.chunkSize))
 		a.chunks = append(a.chunks, a.current)
+		a.noteChunkAdded()
 	}
 	a.chunkCursor++
 	next = int64(n)
-	if next > a.chunkSize {
-		panic(fmt.Sprintf("object size %d is larger than chunk size %d", n, a.chunkSize))
-	}
 	return unsafe.Pointer(&a.current[next-int64(n) : next][0])
 }
 
+// AllocAligned reserves size bytes in the arena aligned to align, which must
+// be a power of two, and returns a pointer to the start of the reservation.
+//
+// This is a low-level primitive for callers building their own typed layouts
+// on top of the arena; [New], [Value] and [Make] already align correctly for
+// the type they allocate.
+func AllocAligned(arena *Arena, size, align int) unsafe.Pointer {
+	p := arena.alignedAlloc(size, align)
+	arena.stats.bytesAllocated.Add(uint64(size))
+	return p
+}
+
+// alignUp rounds n up to the next multiple of align, which must be a power of two.
+func alignUp(n, align int64) int64 {
+	return (n + align - 1) &^ (align - 1)
+}
+
+// alignedAlloc is like alloc but guarantees the returned pointer is aligned to align.
+//
+// Unlike alloc, the fast path is a CAS loop on the pre-aligned cursor rather than a
+// single atomic add, since the amount actually consumed (including padding) isn't
+// known until we've read the current cursor.
+func (a *Arena) alignedAlloc(n, align int) unsafe.Pointer {
+	if int64(n) > a.chunkSize {
+		return a.allocLarge(n)
+	}
+	if a.sharded {
+		return a.allocSharded(n, align)
+	}
+	for {
+		cur := a.cursor.Load()
+		start := alignUp(cur, int64(align))
+		next := start + int64(n)
+		if next > a.chunkSize {
+			return a.resizeAligned(n, align)
+		}
+		if a.cursor.CompareAndSwap(cur, next) {
+			return unsafe.Pointer(&a.current[start:next][0])
+		}
+	}
+}
+
+func (a *Arena) resizeAligned(n, align int) unsafe.Pointer {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.limit != 0 && int(a.chunkCursor) >= a.limit {
+		panic(fmt.Sprintf("arena limit of %d chunks reached", a.limit))
+	}
+	// Another thread may have already expanded the arena or made room in the
+	// current chunk; retry the fast path while holding the lock.
+	var cur int64
+	for {
+		cur = a.cursor.Load()
+		start := alignUp(cur, int64(align))
+		next := start + int64(n)
+		if next > a.chunkSize {
+			break
+		}
+		if a.cursor.CompareAndSwap(cur, next) {
+			return unsafe.Pointer(&a.current[start:next][0])
+		}
+	}
+	a.stats.resizeCount.Add(1)
+	a.stats.wasted.Add(uint64(a.chunkSize - cur))
+	if a.chunkCursor < int64(len(a.chunks)-1) {
+		a.current = a.chunks[a.chunkCursor+1]
+	} else if a.limit == 0 || len(a.chunks) < a.limit {
+		a.current = a.backing.Alloc(int(a.chunkSize))
+		a.chunks = append(a.chunks, a.current)
+		a.noteChunkAdded()
+	}
+	a.chunkCursor++
+	end := int64(n)
+	a.cursor.Store(end)
+	return unsafe.Pointer(&a.current[0:end][0])
+}
+
+// allocLarge handles objects bigger than the chunk size with a dedicated,
+// one-off allocation. These never become "current" and are never reused
+// for subsequent allocations; they are tracked in a.large purely so that
+// [Arena.Reset] and [Arena.Close] can free/zero them.
+func (a *Arena) allocLarge(n int) unsafe.Pointer {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	b := a.backing.Alloc(n)
+	a.large = append(a.large, b)
+	a.noteLargeAdded(n)
+	return unsafe.Pointer(&b[0])
+}
+
 // Reset the arena, zeroing all memory and resetting the cursor.
 //
+// Chunks already allocated from the [Backing] are retained and reused by
+// subsequent allocations rather than freed, so a Reset-and-refill cycle (the
+// common per-request arena idiom) doesn't need to re-Alloc from the Backing
+// every time. Large allocations (see [Make]) are always freed, since they
+// are never reused. On a [WithSharded] arena, each shard keeps only its first
+// chunk; the rest of that shard's chunks are freed back to the Backing.
+//
 // Note that continuing to use any existing data allocated from the arena
 // after a [Reset] will result in undefined behaviour.
 //
@@ -190,17 +337,54 @@ func (a *Arena) resize(n int, next int64) unsafe.Pointer {
 func (a *Arena) Reset() {
 	a.lock.Lock()
 	defer a.lock.Unlock()
-	before := a.cursor.Load()
-	// Zero the chunks.
-	for _, chunk := range a.chunks {
-		for i := range chunk {
-			chunk[i] = 0
+	if a.sharded {
+		a.resetShards()
+	} else {
+		before := a.cursor.Load()
+		// Zero and retain every chunk for reuse, same as Release does when
+		// rewinding to a mark at the very start of the arena.
+		if a.chunkCursor == 0 {
+			zero(a.current[:before])
+		} else {
+			zero(a.chunks[0])
+			for i := int64(1); i < a.chunkCursor; i++ {
+				zero(a.chunks[i])
+			}
+			zero(a.current[:before])
+			a.current = a.chunks[0]
+		}
+		a.chunkCursor = 0
+		if !a.cursor.CompareAndSwap(before, 0) {
+			panic("reset failed, another thread is using the arena")
+		}
+	}
+	for _, chunk := range a.large {
+		a.backing.Free(chunk)
+		a.noteLargeFreed(len(chunk))
+	}
+	a.large = nil
+	a.epoch.Add(1)
+}
+
+// Close releases all memory held by the arena back to its [Backing].
+//
+// The arena must not be used after Close.
+func (a *Arena) Close() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.sharded {
+		a.closeShards()
+	} else {
+		for _, chunk := range a.chunks {
+			a.backing.Free(chunk)
+			a.noteChunkFreed()
 		}
+		a.chunks = nil
+		a.current = nil
 	}
-	a.current = a.chunks[0]
-	a.chunks = [][]byte{a.current}
-	a.chunkCursor = 0
-	if !a.cursor.CompareAndSwap(before, 0) {
-		panic("reset failed, another thread is using the arena")
+	for _, chunk := range a.large {
+		a.backing.Free(chunk)
+		a.noteLargeFreed(len(chunk))
 	}
+	a.large = nil
 }
@@ -2,6 +2,7 @@ package arena
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"unsafe"
 
@@ -19,15 +20,53 @@ type Struct struct {
 	Uint64  uint64
 }
 
-func TestArenaObjectTooLarge(t *testing.T) {
-	arena := Create(4)
-	assert.Panics(t, func() { New[Struct](arena) })
+type countingBacking struct {
+	allocs int
+	frees  int
+}
+
+func (b *countingBacking) Alloc(n int) []byte {
+	b.allocs++
+	return make([]byte, n)
+}
+
+func (b *countingBacking) Free([]byte) {
+	b.frees++
+}
+
+func TestArenaWithBacking(t *testing.T) {
+	backing := &countingBacking{}
+	arena := Create(int(unsafe.Sizeof(Struct{})), WithBacking(backing))
+	assert.Equal(t, 1, backing.allocs)
+	New[Struct](arena) // fits exactly in the first chunk.
+	New[Struct](arena) // first chunk is full, triggers one resize.
+	assert.Equal(t, 2, backing.allocs)
+	arena.Reset()
+	assert.Equal(t, 0, backing.frees, "Reset retains chunks for reuse rather than freeing them")
+	New[Struct](arena)
+	New[Struct](arena)
+	assert.Equal(t, 2, backing.allocs, "refilling after Reset reuses the retained chunks")
+	arena.Close()
+	assert.Equal(t, 2, backing.frees)
+}
+
+func TestArenaLargeAlloc(t *testing.T) {
+	arena := Create(1024)
+	s := Make[byte](arena, 1<<20, 1<<20)
+	for i := range s {
+		s[i] = byte(i)
+	}
+	for i := range s {
+		assert.Equal(t, byte(i), s[i])
+	}
 }
 
 func TestArenaLimit(t *testing.T) {
 	arena := Create(int(unsafe.Sizeof(Struct{})), WithLimit(2))
 	assert.Equal(t, 1, len(arena.chunks))
-	New[Struct](arena)
+	New[Struct](arena) // fits exactly in the first chunk.
+	assert.Equal(t, 1, len(arena.chunks))
+	New[Struct](arena) // first chunk is full, expand into a second.
 	assert.Equal(t, 2, len(arena.chunks))
 	New[Struct](arena)
 	assert.Equal(t, 2, len(arena.chunks), "should not expand once limit is reached")
@@ -84,6 +123,112 @@ func TestAppendShort(t *testing.T) {
 	assert.Equal(t, 1, s[0].Int)
 }
 
+func TestAlignedAlloc(t *testing.T) {
+	arena := Create(1024)
+	for i := range 20 {
+		_ = String(arena, strings.Repeat("x", i%3+1))
+		p := New[uint64](arena)
+		assert.Equal(t, uintptr(0), uintptr(unsafe.Pointer(p))%unsafe.Alignof(*p))
+	}
+}
+
+func TestMarkRelease(t *testing.T) {
+	arena := Create(1024)
+	a := New[Struct](arena)
+	a.Int = 1
+	m := arena.Mark()
+	b := New[Struct](arena)
+	b.Int = 2
+	arena.Release(m)
+	c := New[Struct](arena)
+	assert.Equal(t, unsafe.Pointer(b), unsafe.Pointer(c))
+	assert.Equal(t, 0, c.Int)
+	assert.Equal(t, 1, a.Int)
+}
+
+func TestMarkReleaseAcrossChunks(t *testing.T) {
+	arena := Create(int(unsafe.Sizeof(Struct{})), WithLimit(10))
+	m := arena.Mark()
+	for range 5 {
+		New[Struct](arena)
+	}
+	chunksAfterAlloc := len(arena.chunks)
+	assert.True(t, chunksAfterAlloc > 1)
+	arena.Release(m)
+	// Release rewinds chunkCursor/cursor but leaves already-allocated chunks
+	// in place for reuse by subsequent allocations.
+	assert.Equal(t, chunksAfterAlloc, len(arena.chunks))
+	assert.Equal(t, int64(0), arena.chunkCursor)
+}
+
+func TestMarkReleaseNested(t *testing.T) {
+	arena := Create(1024)
+	outer := arena.Mark()
+	New[Struct](arena)
+	inner := arena.Mark()
+	New[Struct](arena)
+	arena.Release(outer)
+	assert.Panics(t, func() { arena.Release(inner) })
+}
+
+func TestMarkReleaseLargeAlloc(t *testing.T) {
+	arena := Create(64)
+	m := arena.Mark()
+	Make[byte](arena, 1<<10, 1<<10) // too big for a chunk, goes to arena.large
+	assert.Equal(t, uint64(1), arena.Stats().LargeAllocs)
+	arena.Release(m)
+	assert.Equal(t, 0, len(arena.large))
+}
+
+func TestMarkReleaseNestedInnermostFirst(t *testing.T) {
+	// The usual stack/defer idiom releases the innermost mark first; this
+	// must not panic just because an earlier Release happened somewhere.
+	arena := Create(1024)
+	outer := arena.Mark()
+	New[Struct](arena)
+	inner := arena.Mark()
+	New[Struct](arena)
+	arena.Release(inner)
+	arena.Release(outer)
+}
+
+func TestStats(t *testing.T) {
+	size := uint64(unsafe.Sizeof(Struct{}))
+	arena := Create(int(size), WithLimit(10))
+	stats := arena.Stats()
+	assert.Equal(t, uint64(1), stats.ChunksAllocated)
+	assert.Equal(t, uint64(1), stats.ChunksInUse)
+
+	New[Struct](arena) // fits exactly in the first chunk.
+	New[Struct](arena) // first chunk is full, triggers one resize.
+	stats = arena.Stats()
+	assert.Equal(t, 2*size, stats.BytesAllocated)
+	assert.Equal(t, uint64(2), stats.ChunksAllocated)
+	assert.Equal(t, uint64(1), stats.ResizeCount)
+
+	arena.Reset()
+	stats = arena.Stats()
+	assert.Equal(t, uint64(2), stats.ChunksInUse, "Reset retains chunks rather than freeing them")
+	assert.Equal(t, uint64(2), stats.ChunksAllocated, "cumulative counters survive Reset")
+}
+
+func TestStatsLargeAlloc(t *testing.T) {
+	arena := Create(1024)
+	Make[byte](arena, 2048, 2048)
+	stats := arena.Stats()
+	assert.Equal(t, uint64(1), stats.LargeAllocs)
+	assert.Equal(t, uint64(2048), stats.BytesReserved-uint64(1024))
+}
+
+func TestWaste(t *testing.T) {
+	size := int(unsafe.Sizeof(Struct{}))
+	arena := Create(size + size/2) // room for one Struct plus a remainder too small for a second.
+	assert.Equal(t, 0, arena.Waste())
+	New[Struct](arena)
+	New[Struct](arena) // doesn't fit in the remainder, so the arena expands and the remainder is wasted.
+	assert.Equal(t, size/2, arena.Waste())
+}
+
 func TestNewString(t *testing.T) {
 	arena := Create(100)
 	s := String(arena, "hello")
@@ -174,6 +319,46 @@ func BenchmarkGoRuntimeAppend(b *testing.B) {
 	}
 }
 
+func TestSharded(t *testing.T) {
+	arena := Create(1024, WithSharded())
+	a := New[Struct](arena)
+	a.Int = 1
+	b := New[Struct](arena)
+	b.Int = 2
+	assert.Equal(t, 1, a.Int)
+	assert.Equal(t, 2, b.Int)
+	arena.Reset()
+	c := New[Struct](arena)
+	assert.Equal(t, 0, c.Int)
+}
+
+func TestShardedMarkRelease(t *testing.T) {
+	arena := Create(1024, WithSharded())
+	a := New[Struct](arena)
+	a.Int = 1
+	m := arena.Mark()
+	b := New[Struct](arena)
+	b.Int = 2
+	arena.Release(m)
+	c := New[Struct](arena)
+	// If Release actually rewound the shard's cursor, c reuses b's memory.
+	assert.Equal(t, unsafe.Pointer(b), unsafe.Pointer(c))
+	assert.Equal(t, 0, c.Int)
+	assert.Equal(t, 1, a.Int)
+}
+
+func BenchmarkArenaParallel(b *testing.B) {
+	arena := Create(32*1024*1024, WithSharded()) // 32Mb chunk size
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			New[Struct](arena)
+		}
+	})
+}
+
 func BenchmarkReset(b *testing.B) {
 	arena := Create(64 * 1024 * 1024) // 64MB chunk size
 
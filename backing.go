@@ -0,0 +1,22 @@
+package arena
+
+// Backing is a pluggable allocator for the memory backing an [Arena]'s chunks.
+//
+// The default backing allocates chunks on the Go heap, which means the GC
+// has to scan every pointer-containing chunk an arena holds. For arenas
+// that hold many GB of data, implementing Backing over mmap or cgo malloc
+// keeps that memory off-heap and invisible to the collector entirely.
+type Backing interface {
+	// Alloc returns a new zeroed byte slice of length n.
+	Alloc(n int) []byte
+	// Free releases a slice previously returned by Alloc. Implementations
+	// that don't need to release memory early (eg. the Go heap) may make
+	// this a no-op.
+	Free(b []byte)
+}
+
+// goBacking is the default [Backing], allocating chunks on the Go heap.
+type goBacking struct{}
+
+func (goBacking) Alloc(n int) []byte { return make([]byte, n) }
+func (goBacking) Free([]byte)        {}
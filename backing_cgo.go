@@ -0,0 +1,39 @@
+//go:build cgo
+
+package arena
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// cBacking allocates chunks via the C library's malloc/free, keeping them off the Go heap.
+type cBacking struct{}
+
+// NewCBacking returns a [Backing] that allocates chunks via cgo malloc/free,
+// so arena memory is never scanned by the Go garbage collector.
+func NewCBacking() Backing {
+	return cBacking{}
+}
+
+func (cBacking) Alloc(n int) []byte {
+	p := C.malloc(C.size_t(n))
+	if p == nil {
+		panic(fmt.Sprintf("arena: malloc %d bytes failed", n))
+	}
+	b := unsafe.Slice((*byte)(p), n)
+	clear(b)
+	return b
+}
+
+func (cBacking) Free(b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	C.free(unsafe.Pointer(&b[0]))
+}
@@ -0,0 +1,36 @@
+//go:build unix
+
+package arena
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// mmapBacking allocates chunks via anonymous mmap, keeping them off the Go heap.
+type mmapBacking struct{}
+
+// NewMmapBacking returns a [Backing] that allocates chunks via anonymous
+// mmap (MAP_PRIVATE|MAP_ANON), so arena memory is never scanned by the Go
+// garbage collector.
+func NewMmapBacking() Backing {
+	return mmapBacking{}
+}
+
+func (mmapBacking) Alloc(n int) []byte {
+	b, err := unix.Mmap(-1, 0, n, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_PRIVATE|unix.MAP_ANON)
+	if err != nil {
+		panic(fmt.Sprintf("arena: mmap %d bytes: %s", n, err))
+	}
+	return b
+}
+
+func (mmapBacking) Free(b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	if err := unix.Munmap(b); err != nil {
+		panic(fmt.Sprintf("arena: munmap: %s", err))
+	}
+}
@@ -0,0 +1,35 @@
+//go:build unix
+
+package arena
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestMmapBacking(t *testing.T) {
+	backing := NewMmapBacking()
+	b := backing.Alloc(4096)
+	assert.Equal(t, 4096, len(b))
+	for _, v := range b {
+		assert.Equal(t, byte(0), v)
+	}
+	for i := range b {
+		b[i] = byte(i)
+	}
+	for i := range b {
+		assert.Equal(t, byte(i), b[i])
+	}
+	backing.Free(b)
+}
+
+func TestMmapBackingArenaCycle(t *testing.T) {
+	arena := Create(4096, WithBacking(NewMmapBacking()))
+	a := Value[Struct](arena, Struct{Int: 42})
+	assert.Equal(t, 42, a.Int)
+	arena.Reset()
+	b := New[Struct](arena)
+	assert.Equal(t, 0, b.Int)
+	arena.Close()
+}
@@ -0,0 +1,36 @@
+//go:build windows
+
+package arena
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// mmapBacking allocates chunks via VirtualAlloc, keeping them off the Go heap.
+type mmapBacking struct{}
+
+// NewMmapBacking returns a [Backing] that allocates chunks via VirtualAlloc,
+// so arena memory is never scanned by the Go garbage collector.
+func NewMmapBacking() Backing {
+	return mmapBacking{}
+}
+
+func (mmapBacking) Alloc(n int) []byte {
+	addr, err := windows.VirtualAlloc(0, uintptr(n), windows.MEM_COMMIT|windows.MEM_RESERVE, windows.PAGE_READWRITE)
+	if err != nil {
+		panic(fmt.Sprintf("arena: VirtualAlloc %d bytes: %s", n, err))
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(addr)), n)
+}
+
+func (mmapBacking) Free(b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	if err := windows.VirtualFree(uintptr(unsafe.Pointer(&b[0])), 0, windows.MEM_RELEASE); err != nil {
+		panic(fmt.Sprintf("arena: VirtualFree: %s", err))
+	}
+}
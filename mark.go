@@ -0,0 +1,94 @@
+package arena
+
+// Mark is a checkpoint of an [Arena]'s allocation state, captured by [Arena.Mark]
+// and restored by [Arena.Release].
+type Mark struct {
+	chunkCursor int64
+	cursor      int64
+	shards      []shardMark // only set for a [WithSharded] arena
+	large       int
+	epoch       int64
+}
+
+// shardMark is the per-shard equivalent of Mark's chunkCursor/cursor pair.
+type shardMark struct {
+	chunkCursor int64
+	cursor      int64
+}
+
+// Mark captures the arena's current allocation state as a checkpoint.
+//
+// Pass the returned [Mark] to [Arena.Release] to free everything allocated
+// since the mark was taken, without touching individual objects. This is
+// the common scope-based idiom: take a mark at the start of a request,
+// allocate freely while handling it, then release the mark when done.
+//
+// This works on a [WithSharded] arena too: the mark captures every shard's
+// state, and [Arena.Release] rewinds all of them.
+func (a *Arena) Mark() Mark {
+	m := Mark{
+		large: len(a.large),
+		epoch: a.epoch.Load(),
+	}
+	if a.sharded {
+		m.shards = make([]shardMark, len(a.shards))
+		for i, s := range a.shards {
+			m.shards[i] = shardMark{chunkCursor: s.chunkCursor, cursor: s.cursor.Load()}
+		}
+	} else {
+		m.chunkCursor = a.chunkCursor
+		m.cursor = a.cursor.Load()
+	}
+	return m
+}
+
+// Release frees everything allocated in the arena since m was captured,
+// zeroing the released memory and rewinding the cursor to the mark. Large
+// allocations (see [Make]) made since m was captured are freed back to the
+// arena's [Backing].
+//
+// Marks nest: releasing an outer mark implicitly releases any marks taken
+// after it, and releasing marks in the usual stack/defer order (innermost
+// first) is the common case. Releasing a mark taken before a [Arena.Reset]
+// panics, as does releasing a mark that lies beyond the arena's current
+// position, such as one taken after an enclosing mark that has since been
+// released. A mark rewound past by an enclosing release is not detected once
+// later allocations have moved the arena past its position again.
+func (a *Arena) Release(m Mark) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if m.epoch != a.epoch.Load() || m.large > len(a.large) {
+		panic("arena: release of stale mark")
+	}
+	if a.sharded {
+		a.releaseShards(m)
+	} else {
+		if m.chunkCursor > a.chunkCursor ||
+			(m.chunkCursor == a.chunkCursor && m.cursor > a.cursor.Load()) {
+			panic("arena: release of stale mark")
+		}
+		if m.chunkCursor == a.chunkCursor {
+			zero(a.current[m.cursor:a.cursor.Load()])
+		} else {
+			zero(a.chunks[m.chunkCursor][m.cursor:])
+			for i := m.chunkCursor + 1; i < a.chunkCursor; i++ {
+				zero(a.chunks[i])
+			}
+			zero(a.current[:a.cursor.Load()])
+			a.current = a.chunks[m.chunkCursor]
+		}
+		a.chunkCursor = m.chunkCursor
+		a.cursor.Store(m.cursor)
+	}
+	for _, chunk := range a.large[m.large:] {
+		a.backing.Free(chunk)
+		a.noteLargeFreed(len(chunk))
+	}
+	a.large = a.large[:m.large]
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
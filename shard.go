@@ -0,0 +1,155 @@
+package arena
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"unsafe"
+)
+
+// go:linkname into the runtime's own per-P pinning, the same mechanism
+// sync.Pool uses for its per-P pools. Pinning guarantees the returned P id
+// won't change out from under us while we pick a shard.
+//
+//go:linkname runtime_procPin sync.runtime_procPin
+func runtime_procPin() int
+
+//go:linkname runtime_procUnpin sync.runtime_procUnpin
+func runtime_procUnpin()
+
+// shard is a single P's private chunk and cursor within a [WithSharded] arena.
+type shard struct {
+	cursor      atomic.Int64
+	chunkCursor int64
+	current     []byte
+	chunks      [][]byte
+}
+
+// WithSharded gives each P its own chunk and cursor, borrowed from the Go
+// runtime's mcache-per-P design, so concurrent allocations from many
+// goroutines don't serialize on a single cache line. Expanding a shard still
+// takes the arena's shared lock, but the fast path is a contention-free bump
+// on a pinned, per-P slab.
+func WithSharded() Option {
+	return func(a *Arena) {
+		a.sharded = true
+	}
+}
+
+func newShards(a *Arena, chunkSize int) []*shard {
+	shards := make([]*shard, runtime.GOMAXPROCS(0))
+	for i := range shards {
+		chunk := a.backing.Alloc(chunkSize)
+		shards[i] = &shard{current: chunk, chunks: [][]byte{chunk}}
+		a.noteChunkAdded()
+	}
+	return shards
+}
+
+func (a *Arena) allocSharded(n, align int) unsafe.Pointer {
+	pid := runtime_procPin()
+	s := a.shards[pid%len(a.shards)]
+	runtime_procUnpin()
+	for {
+		cur := s.cursor.Load()
+		start := alignUp(cur, int64(align))
+		next := start + int64(n)
+		if next > a.chunkSize {
+			return a.resizeShard(s, n, align)
+		}
+		if s.cursor.CompareAndSwap(cur, next) {
+			return unsafe.Pointer(&s.current[start:next][0])
+		}
+	}
+}
+
+func (a *Arena) resizeShard(s *shard, n, align int) unsafe.Pointer {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.limit != 0 && int(s.chunkCursor)+1 >= a.limit {
+		panic(fmt.Sprintf("arena limit of %d chunks reached", a.limit))
+	}
+	// Another goroutine pinned to this P may have already expanded the shard.
+	var cur int64
+	for {
+		cur = s.cursor.Load()
+		start := alignUp(cur, int64(align))
+		next := start + int64(n)
+		if next > a.chunkSize {
+			break
+		}
+		if s.cursor.CompareAndSwap(cur, next) {
+			return unsafe.Pointer(&s.current[start:next][0])
+		}
+	}
+	a.stats.resizeCount.Add(1)
+	a.stats.wasted.Add(uint64(a.chunkSize - cur))
+	if s.chunkCursor < int64(len(s.chunks)-1) {
+		// Reuse a chunk left in place by an earlier Release.
+		s.current = s.chunks[s.chunkCursor+1]
+	} else {
+		s.current = a.backing.Alloc(int(a.chunkSize))
+		s.chunks = append(s.chunks, s.current)
+		a.noteChunkAdded()
+	}
+	s.chunkCursor++
+	end := int64(n)
+	s.cursor.Store(end)
+	return unsafe.Pointer(&s.current[0:end][0])
+}
+
+// releaseShards is the sharded counterpart of the non-sharded rewind in
+// [Arena.Release]: every shard is checked against its captured state and
+// rewound independently, since each shard has its own cursor and chunks.
+func (a *Arena) releaseShards(m Mark) {
+	if len(m.shards) != len(a.shards) {
+		panic("arena: release of stale mark")
+	}
+	for i, s := range a.shards {
+		sm := m.shards[i]
+		if sm.chunkCursor > s.chunkCursor ||
+			(sm.chunkCursor == s.chunkCursor && sm.cursor > s.cursor.Load()) {
+			panic("arena: release of stale mark")
+		}
+	}
+	for i, s := range a.shards {
+		sm := m.shards[i]
+		if sm.chunkCursor == s.chunkCursor {
+			zero(s.current[sm.cursor:s.cursor.Load()])
+		} else {
+			zero(s.chunks[sm.chunkCursor][sm.cursor:])
+			for j := sm.chunkCursor + 1; j < s.chunkCursor; j++ {
+				zero(s.chunks[j])
+			}
+			zero(s.current[:s.cursor.Load()])
+			s.current = s.chunks[sm.chunkCursor]
+		}
+		s.chunkCursor = sm.chunkCursor
+		s.cursor.Store(sm.cursor)
+	}
+}
+
+func (a *Arena) resetShards() {
+	for _, s := range a.shards {
+		first := s.chunks[0]
+		zero(first)
+		for _, chunk := range s.chunks[1:] {
+			a.backing.Free(chunk)
+			a.noteChunkFreed()
+		}
+		s.current = first
+		s.chunks = s.chunks[:1]
+		s.chunkCursor = 0
+		s.cursor.Store(0)
+	}
+}
+
+func (a *Arena) closeShards() {
+	for _, s := range a.shards {
+		for _, chunk := range s.chunks {
+			a.backing.Free(chunk)
+			a.noteChunkFreed()
+		}
+	}
+	a.shards = nil
+}
@@ -0,0 +1,99 @@
+package arena
+
+import "sync/atomic"
+
+// Stats describes an [Arena]'s memory usage, modelled on [runtime.MemStats].
+//
+// BytesAllocated, ChunksAllocated, LargeAllocs and ResizeCount are cumulative
+// counters covering the arena's entire lifetime, including allocations freed
+// by a subsequent [Arena.Reset]. BytesReserved and ChunksInUse describe the
+// arena's current, live footprint.
+type Stats struct {
+	// BytesAllocated is the total number of bytes handed out to callers.
+	BytesAllocated uint64
+	// BytesReserved is the number of bytes currently reserved from the Backing,
+	// across all chunks and large allocations.
+	BytesReserved uint64
+	// ChunksAllocated is the total number of chunks ever allocated from the Backing.
+	ChunksAllocated uint64
+	// ChunksInUse is the number of chunks currently reserved from the Backing.
+	ChunksInUse uint64
+	// LargeAllocs is the number of allocations that didn't fit in a chunk.
+	LargeAllocs uint64
+	// HighWaterMark is the largest value BytesReserved has ever reached.
+	HighWaterMark uint64
+	// ResizeCount is the number of times an allocation didn't fit in the
+	// current chunk and had to expand or advance the arena.
+	ResizeCount uint64
+}
+
+// arenaStats holds the atomic counters backing [Arena.Stats], updated outside
+// the arena's lock wherever possible so they don't slow down the fast path.
+type arenaStats struct {
+	bytesAllocated  atomic.Uint64
+	bytesReserved   atomic.Uint64
+	chunksAllocated atomic.Uint64
+	chunksInUse     atomic.Uint64
+	largeAllocs     atomic.Uint64
+	highWaterMark   atomic.Uint64
+	resizeCount     atomic.Uint64
+	wasted          atomic.Uint64
+}
+
+// noteChunkAdded records a new chunk reserved from the Backing. Callers must hold a.lock.
+func (a *Arena) noteChunkAdded() {
+	a.stats.chunksAllocated.Add(1)
+	a.stats.chunksInUse.Add(1)
+	a.noteReserved(a.chunkSize)
+}
+
+// noteChunkFreed records a chunk released back to the Backing. Callers must hold a.lock.
+func (a *Arena) noteChunkFreed() {
+	a.stats.chunksInUse.Add(^uint64(0)) // -1
+	a.noteFreed(a.chunkSize)
+}
+
+// noteLargeAdded records an oversize allocation reserved from the Backing. Callers must hold a.lock.
+func (a *Arena) noteLargeAdded(n int) {
+	a.stats.largeAllocs.Add(1)
+	a.noteReserved(int64(n))
+}
+
+// noteLargeFreed records an oversize allocation released back to the Backing. Callers must hold a.lock.
+func (a *Arena) noteLargeFreed(n int) {
+	a.noteFreed(int64(n))
+}
+
+func (a *Arena) noteReserved(n int64) {
+	reserved := a.stats.bytesReserved.Add(uint64(n))
+	for {
+		hw := a.stats.highWaterMark.Load()
+		if reserved <= hw || a.stats.highWaterMark.CompareAndSwap(hw, reserved) {
+			return
+		}
+	}
+}
+
+func (a *Arena) noteFreed(n int64) {
+	a.stats.bytesReserved.Add(^uint64(n - 1)) // -n
+}
+
+// Stats returns a snapshot of the arena's memory usage.
+func (a *Arena) Stats() Stats {
+	return Stats{
+		BytesAllocated:  a.stats.bytesAllocated.Load(),
+		BytesReserved:   a.stats.bytesReserved.Load(),
+		ChunksAllocated: a.stats.chunksAllocated.Load(),
+		ChunksInUse:     a.stats.chunksInUse.Load(),
+		LargeAllocs:     a.stats.largeAllocs.Load(),
+		HighWaterMark:   a.stats.highWaterMark.Load(),
+		ResizeCount:     a.stats.resizeCount.Load(),
+	}
+}
+
+// Waste returns the total number of bytes lost to end-of-chunk fragmentation
+// over the arena's lifetime: space left over in chunks that was too small to
+// satisfy the allocation that triggered expansion into the next chunk.
+func (a *Arena) Waste() int {
+	return int(a.stats.wasted.Load())
+}